@@ -1,10 +1,18 @@
 package commands
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	base_config "github.com/onedr0p/exportarr/internal/config"
 	"github.com/onedr0p/exportarr/internal/sabnzbd/collector"
 	"github.com/onedr0p/exportarr/internal/sabnzbd/config"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func init() {
@@ -22,6 +30,21 @@ var sabnzbdCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+
+		if c.TargetsFile != "" {
+			targets, err := config.LoadTargetsConfig(c.TargetsFile)
+			if err != nil {
+				return err
+			}
+			// Registered from inside serveHttp's callback so /probe shares
+			// whatever mux/listener it sets up for /metrics, rather than
+			// assuming it happens to run on http.DefaultServeMux.
+			serveHttp(func(r prometheus.Registerer) {
+				http.HandleFunc("/probe", probeSabnzbdHandler(targets))
+			})
+			return nil
+		}
+
 		if err := c.Validate(); err != nil {
 			return err
 		}
@@ -32,7 +55,92 @@ var sabnzbdCmd = &cobra.Command{
 		}
 		serveHttp(func(r prometheus.Registerer) {
 			r.MustRegister(collector)
+			if c.INIConfig != "" {
+				go watchSabnzbdConfig(cmd.Context(), c, r, collector)
+			}
 		})
 		return nil
 	},
 }
+
+// watchSabnzbdConfig swaps the registered SabnzbdCollector for a new one
+// whenever the underlying sabnzbd.ini changes, so the exporter picks up a
+// new URL or API key (e.g. after Sabnzbd rewrites its ini on a port change)
+// without a restart.
+func watchSabnzbdConfig(ctx context.Context, c *config.SabnzbdConfig, r prometheus.Registerer, active prometheus.Collector) {
+	updates, err := c.Watch(ctx)
+	if err != nil {
+		log.Printf("sabnzbd: not watching %s for changes: %v", c.INIConfig, err)
+		return
+	}
+
+	for updated := range updates {
+		next, err := collector.NewSabnzbdCollector(updated)
+		if err != nil {
+			log.Printf("sabnzbd: reloaded config from %s is invalid, keeping previous collector: %v", c.INIConfig, err)
+			continue
+		}
+		r.Unregister(active)
+		r.MustRegister(next)
+		active = next
+	}
+}
+
+// probeSabnzbdHandler builds a one-shot SabnzbdCollector for the instance
+// named by the "target" or "instance" query parameter and serves its
+// metrics, blackbox_exporter-style, so a single exporter process can cover
+// many Sabnzbd instances.
+func probeSabnzbdHandler(targets *config.TargetsConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := config.TargetConfig{
+			URL:    r.URL.Query().Get("target"),
+			ApiKey: r.URL.Query().Get("api_key"),
+		}
+		if instance := r.URL.Query().Get("instance"); instance != "" {
+			found := targets.Find(instance)
+			if found == nil {
+				http.Error(w, fmt.Sprintf("unknown instance %q", instance), http.StatusBadRequest)
+				return
+			}
+			target = *found
+		}
+		if target.URL == "" {
+			http.Error(w, "target or instance is required", http.StatusBadRequest)
+			return
+		}
+
+		flags := pflag.NewFlagSet("probe", pflag.ContinueOnError)
+		config.RegisterSabnzbdFlags(flags)
+		if target.INIConfig != "" {
+			if err := flags.Set("config", target.INIConfig); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		c, err := config.LoadSabnzbdConfig(base_config.Config{
+			App:              "sabnzbd",
+			URL:              target.URL,
+			ApiKey:           target.ApiKey,
+			DisableSSLVerify: target.DisableSSLVerify,
+		}, flags)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := c.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		probeCollector, err := collector.NewSabnzbdCollector(c)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(probeCollector)
+		promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onedr0p/exportarr/internal/sabnzbd/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeSabnzbdHandler_MissingTargetAndInstance(t *testing.T) {
+	require := require.New(t)
+
+	targets := &config.TargetsConfig{}
+	req := httptest.NewRequest("GET", "/probe", nil)
+	rec := httptest.NewRecorder()
+
+	probeSabnzbdHandler(targets)(rec, req)
+
+	require.Equal(400, rec.Code)
+}
+
+func TestProbeSabnzbdHandler_UnknownInstance(t *testing.T) {
+	require := require.New(t)
+
+	targets := &config.TargetsConfig{}
+	req := httptest.NewRequest("GET", "/probe?instance=missing", nil)
+	rec := httptest.NewRecorder()
+
+	probeSabnzbdHandler(targets)(rec, req)
+
+	require.Equal(400, rec.Code)
+}
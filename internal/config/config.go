@@ -0,0 +1,59 @@
+// Package config provides the configuration primitives shared across all
+// of exportarr's per-app exporters (sabnzbd, sonarr, radarr, etc.).
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Config holds the base configuration options common to every exporter,
+// populated from the root command's persistent flags before being handed
+// to each app-specific loader (e.g. sabnzbd's LoadSabnzbdConfig).
+type Config struct {
+	App              string
+	URL              string
+	ApiKey           string
+	DisableSSLVerify bool
+}
+
+// ExpandURL expands shorthand forms of a target URL into a fully qualified
+// one, the same shorthand model Tailscale's `serve` command uses for its
+// proxy argument:
+//
+//   - a bare port, e.g. "8080", expands to "http://127.0.0.1:8080"
+//   - "host:port" expands to "http://host:port"
+//   - "https+insecure://host" becomes "https://host" with insecure=true
+//   - a URL missing a scheme defaults to "http"
+//
+// It returns the expanded target URL and whether SSL verification should be
+// disabled for it. An empty raw value is returned unchanged.
+func ExpandURL(raw string) (target string, insecure bool, err error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", false, nil
+	}
+
+	if rest, ok := strings.CutPrefix(raw, "https+insecure://"); ok {
+		return "https://" + rest, true, nil
+	}
+
+	if port, convErr := strconv.Atoi(raw); convErr == nil {
+		if port < 1 || port > 65535 {
+			return "", false, fmt.Errorf("invalid port %q: must be between 1 and 65535", raw)
+		}
+		return fmt.Sprintf("http://127.0.0.1:%d", port), false, nil
+	}
+
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid url %q: %w", raw, err)
+	}
+	return u.String(), false, nil
+}
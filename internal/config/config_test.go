@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandURL(t *testing.T) {
+	params := []struct {
+		name         string
+		raw          string
+		wantTarget   string
+		wantInsecure bool
+		wantErr      bool
+	}{
+		{
+			name:       "empty",
+			raw:        "",
+			wantTarget: "",
+		},
+		{
+			name:       "bare-port",
+			raw:        "8080",
+			wantTarget: "http://127.0.0.1:8080",
+		},
+		{
+			name:       "host-port",
+			raw:        "sabnzbd.example.com:8080",
+			wantTarget: "http://sabnzbd.example.com:8080",
+		},
+		{
+			name:         "https-insecure",
+			raw:          "https+insecure://sabnzbd.example.com",
+			wantTarget:   "https://sabnzbd.example.com",
+			wantInsecure: true,
+		},
+		{
+			name:       "missing-scheme",
+			raw:        "sabnzbd.example.com",
+			wantTarget: "http://sabnzbd.example.com",
+		},
+		{
+			name:       "full-url-unchanged",
+			raw:        "https://sabnzbd.example.com:9090",
+			wantTarget: "https://sabnzbd.example.com:9090",
+		},
+		{
+			name:    "invalid-url",
+			raw:     "http://[::1",
+			wantErr: true,
+		},
+		{
+			name:    "negative-port",
+			raw:     "-5",
+			wantErr: true,
+		},
+		{
+			name:    "port-out-of-range",
+			raw:     "99999",
+			wantErr: true,
+		},
+	}
+	for _, p := range params {
+		t.Run(p.name, func(t *testing.T) {
+			require := require.New(t)
+			target, insecure, err := ExpandURL(p.raw)
+			if p.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(p.wantTarget, target)
+			require.Equal(p.wantInsecure, insecure)
+		})
+	}
+}
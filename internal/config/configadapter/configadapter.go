@@ -0,0 +1,44 @@
+// Package configadapter lets every *arr exporter accept its native app
+// config file (sabnzbd.ini, and eventually a sonarr/radarr config.xml) or a
+// user-authored YAML/TOML/JSON file through the same loading pipeline,
+// in the spirit of Caddy's config adapters: a small, named translator from
+// "whatever format this app speaks" to the flat key/value map koanf expects.
+package configadapter
+
+import "strings"
+
+// Warning describes a non-fatal issue encountered while adapting a config
+// file, e.g. a key the adapter didn't recognize and ignored.
+type Warning struct {
+	Message string
+}
+
+// Adapter turns a config file's raw bytes into a flat map of configuration
+// values suitable for koanf's confmap.Provider.
+type Adapter interface {
+	Adapt(body []byte) (map[string]any, []Warning, error)
+}
+
+var adapters = map[string]Adapter{}
+
+// Register adds an adapter under the given name, making it selectable via
+// the matching file extension (".<name>") or an explicit --config-adapter
+// flag. It panics if name is already registered, mirroring how
+// database/sql and image guard their own driver registries.
+func Register(name string, a Adapter) {
+	if _, exists := adapters[name]; exists {
+		panic("configadapter: Register called twice for adapter " + name)
+	}
+	adapters[name] = a
+}
+
+// Get returns the adapter registered under name, or nil if none is.
+func Get(name string) Adapter {
+	return adapters[strings.ToLower(name)]
+}
+
+// ForExt returns the adapter registered for a file extension such as
+// ".ini" or "yaml", or nil if none is registered for it.
+func ForExt(ext string) Adapter {
+	return Get(strings.TrimPrefix(ext, "."))
+}
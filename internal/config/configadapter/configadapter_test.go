@@ -0,0 +1,59 @@
+package configadapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	require := require.New(t)
+	require.NotNil(Get("ini"))
+	require.NotNil(Get("YAML"))
+	require.Nil(Get("sonarr-xml"))
+}
+
+func TestForExt(t *testing.T) {
+	require := require.New(t)
+	require.NotNil(ForExt(".ini"))
+	require.NotNil(ForExt("toml"))
+	require.Nil(ForExt(".xml"))
+}
+
+func TestRegister_PanicsOnDuplicate(t *testing.T) {
+	require := require.New(t)
+	require.Panics(func() {
+		Register("ini", iniAdapter{})
+	})
+}
+
+func TestIniAdapter_Adapt(t *testing.T) {
+	require := require.New(t)
+
+	body := []byte(`
+[misc]
+host = 0.0.0.0
+port = 8080
+api_key = "abcdef0123456789abcdef0123456789"
+
+not a valid line
+`)
+
+	m, warnings, err := Get("ini").Adapt(body)
+	require.NoError(err)
+	require.Equal("0.0.0.0", m["misc.host"])
+	require.Equal("8080", m["misc.port"])
+	require.Equal("abcdef0123456789abcdef0123456789", m["misc.api_key"])
+	require.Len(warnings, 1)
+}
+
+func TestEnvAdapter_Adapt(t *testing.T) {
+	require := require.New(t)
+
+	body := []byte("SAB_URL=http://localhost:8080\nSAB_API__KEY=abc\n# a comment\n")
+
+	m, _, err := Get("env").Adapt(body)
+	require.NoError(err)
+	require.Equal("http://localhost:8080", m["sab-url"])
+	require.Equal("abc", m["sab-api.key"])
+}
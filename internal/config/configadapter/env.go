@@ -0,0 +1,50 @@
+package configadapter
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	Register("env", envAdapter{})
+}
+
+// envAdapter adapts a dotenv-style file (KEY=value per line) into a flat
+// map, lowercasing and dash-casing keys the same way exportarr's
+// environment variable provider does.
+type envAdapter struct{}
+
+func (envAdapter) Adapt(body []byte) (map[string]any, []Warning, error) {
+	result := make(map[string]any)
+	var warnings []Warning
+
+	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			warnings = append(warnings, Warning{Message: fmt.Sprintf("ignoring unparseable line %q", line)})
+			continue
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		key = strings.ReplaceAll(key, "__", ".")
+		key = strings.ReplaceAll(key, "_", "-")
+
+		value = strings.TrimSpace(value)
+		value = strings.Trim(value, `"'`)
+
+		result[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("scanning env file: %w", err)
+	}
+
+	return result, warnings, nil
+}
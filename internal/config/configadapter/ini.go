@@ -0,0 +1,63 @@
+package configadapter
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	Register("ini", iniAdapter{})
+}
+
+// iniAdapter adapts INI-formatted config files, such as sabnzbd.ini, into
+// a flat "section.key" map.
+type iniAdapter struct{}
+
+// Adapt parses INI content with sections like [misc] and key = value pairs
+// into a map keyed as "section.key".
+func (iniAdapter) Adapt(body []byte) (map[string]any, []Warning, error) {
+	result := make(map[string]any)
+	var warnings []Warning
+	currentSection := "misc" // default section for files like sabnzbd.ini
+
+	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		// Skip version and encoding headers
+		if strings.Contains(line, "sabnzbd.ini_version__") || strings.Contains(line, "__encoding__") {
+			continue
+		}
+
+		// Check for section header [section]
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			currentSection = strings.Trim(line, "[]")
+			continue
+		}
+
+		// Parse key = value
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			warnings = append(warnings, Warning{Message: fmt.Sprintf("ignoring unparseable line %q", line)})
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+		value = strings.Trim(value, `"`)
+
+		result[currentSection+"."+key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("scanning ini file: %w", err)
+	}
+
+	return result, warnings, nil
+}
@@ -0,0 +1,34 @@
+package configadapter
+
+import (
+	"github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/parsers/toml"
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+func init() {
+	Register("yaml", parserAdapter{yaml.Parser()})
+	Register("yml", parserAdapter{yaml.Parser()})
+	Register("toml", parserAdapter{toml.Parser()})
+	Register("json", parserAdapter{json.Parser()})
+}
+
+// koanfParser is the subset of koanf.Parser that parserAdapter needs.
+type koanfParser interface {
+	Unmarshal([]byte) (map[string]any, error)
+}
+
+// parserAdapter adapts a koanf parser (which already turns a structured
+// format into a flat map) into an Adapter, for formats users hand-author
+// themselves rather than ones native to the *arr apps.
+type parserAdapter struct {
+	parser koanfParser
+}
+
+func (a parserAdapter) Adapt(body []byte) (map[string]any, []Warning, error) {
+	m, err := a.parser.Unmarshal(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, nil, nil
+}
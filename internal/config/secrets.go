@@ -0,0 +1,228 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// SecretProvider resolves a secret reference (the part of a "scheme:ref"
+// value after the scheme) to its plaintext value.
+type SecretProvider interface {
+	Resolve(ref string) (string, error)
+}
+
+// secretProviders maps a scheme prefix (e.g. "file", "env") to the provider
+// that resolves references of that scheme. New backends register here
+// without any change to the fields that use them.
+var secretProviders = map[string]SecretProvider{
+	"file":  fileSecretProvider{},
+	"env":   envSecretProvider{},
+	"vault": vaultSecretProvider{},
+	"exec":  execSecretProvider{},
+}
+
+// secretRefPattern matches a "scheme:rest" secret reference.
+var secretRefPattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+]*):(.+)$`)
+
+// ResolveSecrets walks the exported string fields of v (a pointer to a
+// struct) tagged `secret:"true"` and, for any value matching a registered
+// "scheme:ref" secret reference, replaces it with the value resolved from
+// the corresponding SecretProvider. Fields whose value doesn't match a
+// registered scheme are left untouched, so a literal secret still works.
+func ResolveSecrets(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ResolveSecrets: expected a pointer to a struct, got %T", v)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Tag.Get("secret") != "true" {
+			continue
+		}
+		fv := rv.Field(i)
+		if fv.Kind() != reflect.String {
+			continue
+		}
+
+		matches := secretRefPattern.FindStringSubmatch(fv.String())
+		if matches == nil {
+			continue
+		}
+		provider, ok := secretProviders[matches[1]]
+		if !ok {
+			continue
+		}
+
+		resolved, err := provider.Resolve(matches[2])
+		if err != nil {
+			return fmt.Errorf("resolving secret for field %s: %w", field.Name, err)
+		}
+		fv.SetString(resolved)
+	}
+	return nil
+}
+
+// fileSecretProvider resolves "file:/path/to/secret" references by reading
+// the trimmed contents of the file, e.g. a mounted Docker or Kubernetes secret.
+type fileSecretProvider struct{}
+
+func (fileSecretProvider) Resolve(ref string) (string, error) {
+	b, err := os.ReadFile(ref)
+	if err != nil {
+		return "", fmt.Errorf("reading secret file %q: %w", ref, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// envSecretProvider resolves "env:NAME" references from the environment.
+type envSecretProvider struct{}
+
+func (envSecretProvider) Resolve(ref string) (string, error) {
+	v, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q is not set", ref)
+	}
+	return v, nil
+}
+
+// execSecretProvider resolves "exec:/path/to/cmd [args...]" references by
+// running the command once at startup and capturing its trimmed stdout.
+type execSecretProvider struct{}
+
+func (execSecretProvider) Resolve(ref string) (string, error) {
+	fields := strings.Fields(ref)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("exec secret reference is empty")
+	}
+	out, err := exec.Command(fields[0], fields[1:]...).Output()
+	if err != nil {
+		return "", fmt.Errorf("running exec secret command %q: %w", ref, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// vaultSecretProvider resolves "vault:secret/path#field" references from a
+// HashiCorp Vault KV v2 engine, authenticating with VAULT_TOKEN or, failing
+// that, the VAULT_ROLE_ID/VAULT_SECRET_ID AppRole pair.
+type vaultSecretProvider struct{}
+
+func (vaultSecretProvider) Resolve(ref string) (string, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok {
+		return "", fmt.Errorf("vault secret reference %q must be in the form secret/path#field", ref)
+	}
+
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		addr = "https://127.0.0.1:8200"
+	}
+
+	token, err := vaultToken(addr)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := vaultKVv2Read(addr, token, path)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("vault secret %q has no field %q", path, field)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %q field %q is not a string", path, field)
+	}
+	return s, nil
+}
+
+// vaultToken returns a Vault token from VAULT_TOKEN, or logs in via AppRole
+// using VAULT_ROLE_ID/VAULT_SECRET_ID if the former isn't set.
+func vaultToken(addr string) (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	roleID := os.Getenv("VAULT_ROLE_ID")
+	secretID := os.Getenv("VAULT_SECRET_ID")
+	if roleID == "" || secretID == "" {
+		return "", fmt.Errorf("vault secret provider requires VAULT_TOKEN or VAULT_ROLE_ID/VAULT_SECRET_ID")
+	}
+
+	body, err := json.Marshal(map[string]string{"role_id": roleID, "secret_id": secretID})
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.Post(addr+"/v1/auth/approle/login", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("vault approle login: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("vault approle login: decoding response: %w", err)
+	}
+	if out.Auth.ClientToken == "" {
+		return "", fmt.Errorf("vault approle login: no client token returned")
+	}
+	return out.Auth.ClientToken, nil
+}
+
+// vaultKVv2Read reads the "data.data" payload of a KV v2 secret at path.
+func vaultKVv2Read(addr, token, path string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/v1/%s", addr, kvV2DataPath(path))
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault read %q: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("vault read %q: unexpected status %d: %s", path, resp.StatusCode, string(b))
+	}
+
+	var out struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("vault read %q: decoding response: %w", path, err)
+	}
+	return out.Data.Data, nil
+}
+
+// kvV2DataPath inserts the "data" segment KV v2 requires after the mount,
+// e.g. "secret/myapp" becomes "secret/data/myapp".
+func kvV2DataPath(path string) string {
+	mount, rest, ok := strings.Cut(path, "/")
+	if !ok {
+		return path
+	}
+	return mount + "/data/" + rest
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type secretTestConfig struct {
+	ApiKey string `secret:"true"`
+	Other  string
+}
+
+func TestResolveSecrets_File(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "api-key")
+	require.NoError(os.WriteFile(path, []byte("  abcdef0123456789abcdef0123456789\n"), 0o600))
+
+	c := &secretTestConfig{ApiKey: "file:" + path}
+	require.NoError(ResolveSecrets(c))
+	require.Equal("abcdef0123456789abcdef0123456789", c.ApiKey)
+}
+
+func TestResolveSecrets_Env(t *testing.T) {
+	require := require.New(t)
+	t.Setenv("SAB_TEST_API_KEY", "abcdef0123456789abcdef0123456789")
+
+	c := &secretTestConfig{ApiKey: "env:SAB_TEST_API_KEY"}
+	require.NoError(ResolveSecrets(c))
+	require.Equal("abcdef0123456789abcdef0123456789", c.ApiKey)
+}
+
+func TestResolveSecrets_Exec(t *testing.T) {
+	require := require.New(t)
+
+	c := &secretTestConfig{ApiKey: "exec:echo abcdef0123456789abcdef0123456789"}
+	require.NoError(ResolveSecrets(c))
+	require.Equal("abcdef0123456789abcdef0123456789", c.ApiKey)
+}
+
+func TestResolveSecrets_UntaggedFieldUntouched(t *testing.T) {
+	require := require.New(t)
+
+	c := &secretTestConfig{ApiKey: "literal-value", Other: "env:SOME_VAR"}
+	require.NoError(ResolveSecrets(c))
+	require.Equal("literal-value", c.ApiKey)
+	require.Equal("env:SOME_VAR", c.Other)
+}
+
+func TestResolveSecrets_LiteralValueUntouched(t *testing.T) {
+	require := require.New(t)
+
+	c := &secretTestConfig{ApiKey: "abcdef0123456789abcdef0123456789"}
+	require.NoError(ResolveSecrets(c))
+	require.Equal("abcdef0123456789abcdef0123456789", c.ApiKey)
+}
+
+func TestResolveSecrets_UnknownScheme(t *testing.T) {
+	require := require.New(t)
+
+	c := &secretTestConfig{ApiKey: "sops:secrets.yaml"}
+	require.NoError(ResolveSecrets(c))
+	require.Equal("sops:secrets.yaml", c.ApiKey)
+}
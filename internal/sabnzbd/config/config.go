@@ -26,22 +26,28 @@
 package config
 
 import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/gookit/validate"
 	"github.com/knadh/koanf/providers/confmap"
 	"github.com/knadh/koanf/providers/env"
-	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/providers/posflag"
 	"github.com/knadh/koanf/v2"
 	flag "github.com/spf13/pflag"
 
 	base_config "github.com/onedr0p/exportarr/internal/config"
+	"github.com/onedr0p/exportarr/internal/config/configadapter"
 )
 
 // RegisterSabnzbdFlags registers command-line flags for Sabnzbd configuration.
 func RegisterSabnzbdFlags(flags *flag.FlagSet) {
 	flags.StringP("config", "c", "", "sabnzbd.ini config file for parsing authentication information")
+	flags.String("config-adapter", "", "configadapter to use for --config (default: inferred from its file extension, falling back to ini)")
+	flags.String("targets", "", "YAML/TOML file listing named Sabnzbd instances to serve on /probe; when set, the single-instance /metrics endpoint is disabled")
 }
 
 // SabnzbdConfig holds the configuration for Sabnzbd exporter.
@@ -49,9 +55,15 @@ type SabnzbdConfig struct {
 	App              string `koanf:"app"`
 	INIConfig        string `koanf:"config"`
 	URL              string `koanf:"url" validate:"required|url"`
-	ApiKey           string `koanf:"api-key" validate:"required|regex:(^[a-zA-Z0-9]{20,32}$)"`
+	ApiKey           string `koanf:"api-key" secret:"true" validate:"required|regex:(^[a-zA-Z0-9]{20,32}$)"`
 	DisableSSLVerify bool   `koanf:"disable-ssl-verify"`
+	TargetsFile      string `koanf:"targets"`
 	k                *koanf.Koanf
+
+	// conf and flags are retained so Watch can re-run LoadSabnzbdConfig with
+	// the same inputs when the underlying sabnzbd.ini changes.
+	conf  base_config.Config
+	flags *flag.FlagSet
 }
 
 // LoadSabnzbdConfig loads Sabnzbd configuration from defaults, environment variables,
@@ -81,8 +93,9 @@ func LoadSabnzbdConfig(conf base_config.Config, flags *flag.FlagSet) (*SabnzbdCo
 		return nil, err
 	}
 
-	// Environment
-	err = k.Load(env.Provider("", ".", func(s string) string {
+	// Environment (e.g. SAB_CONFIG -> config, SAB_API_KEY -> api-key)
+	err = k.Load(env.Provider("SAB_", ".", func(s string) string {
+		s = strings.TrimPrefix(s, "SAB_")
 		s = strings.ToLower(s)
 		s = strings.ReplaceAll(s, "__", ".")
 		s = strings.ReplaceAll(s, "_", "-")
@@ -100,7 +113,25 @@ func LoadSabnzbdConfig(conf base_config.Config, flags *flag.FlagSet) (*SabnzbdCo
 	// INIConfig
 	iniConfig := k.String("config")
 	if iniConfig != "" {
-		err := k.Load(file.Provider(iniConfig), INIParser(), koanf.WithMergeFunc(INIParser().Merge(conf.URL)))
+		adapter, err := selectConfigAdapter(iniConfig, k.String("config-adapter"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := os.ReadFile(iniConfig)
+		if err != nil {
+			return nil, fmt.Errorf("reading config file %s: %w", iniConfig, err)
+		}
+
+		adapted, warnings, err := adapter.Adapt(body)
+		if err != nil {
+			return nil, fmt.Errorf("adapting config file %s: %w", iniConfig, err)
+		}
+		for _, w := range warnings {
+			log.Printf("config: %s: %s", iniConfig, w.Message)
+		}
+
+		err = k.Load(confmap.Provider(adapted, "."), nil, koanf.WithMergeFunc(INIParser().Merge(conf.URL)))
 		if err != nil {
 			return nil, err
 		}
@@ -112,13 +143,56 @@ func LoadSabnzbdConfig(conf base_config.Config, flags *flag.FlagSet) (*SabnzbdCo
 		ApiKey:           conf.ApiKey,
 		DisableSSLVerify: conf.DisableSSLVerify,
 		k:                k,
+		conf:             conf,
+		flags:            flags,
 	}
 	if err = k.Unmarshal("", out); err != nil {
 		return nil, err
 	}
+
+	// Expand shorthand URL forms (bare port, host:port, https+insecure://)
+	// before validation sees them.
+	target, insecure, err := base_config.ExpandURL(out.URL)
+	if err != nil {
+		return nil, err
+	}
+	out.URL = target
+	if insecure {
+		out.DisableSSLVerify = true
+	}
+
+	// Resolve secret-backed fields (e.g. ApiKey set to "vault:secret/sab#key")
+	// before validation sees them.
+	if err := base_config.ResolveSecrets(out); err != nil {
+		return nil, err
+	}
+
 	return out, nil
 }
 
+// selectConfigAdapter picks the configadapter.Adapter to use for path,
+// preferring an explicit name (the --config-adapter flag) over the file's
+// extension, and falling back to "ini" for sabnzbd.ini's sake.
+func selectConfigAdapter(path, explicit string) (configadapter.Adapter, error) {
+	if explicit != "" {
+		adapter := configadapter.Get(explicit)
+		if adapter == nil {
+			return nil, fmt.Errorf("no config adapter registered for %q", explicit)
+		}
+		return adapter, nil
+	}
+
+	if adapter := configadapter.ForExt(filepath.Ext(path)); adapter != nil {
+		return adapter, nil
+	}
+
+	adapter := configadapter.Get("ini")
+	if adapter == nil {
+		return nil, fmt.Errorf("no config adapter registered for %q", filepath.Ext(path))
+	}
+	return adapter, nil
+}
+
 // Validate validates the Sabnzbd configuration.
 func (c *SabnzbdConfig) Validate() error {
 	v := validate.Struct(c)
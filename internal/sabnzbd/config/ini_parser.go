@@ -1,77 +1,26 @@
 package config
 
 import (
-	"bufio"
-	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+
+	base_config "github.com/onedr0p/exportarr/internal/config"
 )
 
-// INI represents the INI parser helper for sabnzbd.ini files.
+// INI holds the Sabnzbd-specific merge behavior applied on top of the
+// generic "ini" configadapter: turning the parsed [misc] section into the
+// url/api-key fields SabnzbdConfig expects. Parsing the INI syntax itself
+// now lives in the configadapter "ini" adapter, shared by every *arr
+// exporter that accepts a native config file.
 type INI struct{}
 
-// INIParser returns a new INI parser instance.
+// INIParser returns a new INI merge helper.
 func INIParser() *INI {
 	return &INI{}
 }
 
-// Unmarshal parses INI file content and returns a map of configuration values.
-// It handles sabnzbd.ini format with sections like [misc] and key-value pairs.
-func (p *INI) Unmarshal(b []byte) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
-	currentSection := "misc" // Default section for sabnzbd.ini
-	
-	scanner := bufio.NewScanner(strings.NewReader(string(b)))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
-			continue
-		}
-		
-		// Skip version and encoding headers
-		if strings.Contains(line, "sabnzbd.ini_version__") || strings.Contains(line, "__encoding__") {
-			continue
-		}
-		
-		// Check for section header [section]
-		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
-			currentSection = strings.Trim(line, "[]")
-			continue
-		}
-		
-		// Parse key = value
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		
-		// Remove quotes if present
-		value = strings.Trim(value, `"`)
-		
-		// Store as section.key
-		fullKey := currentSection + "." + key
-		result[fullKey] = value
-	}
-	
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error scanning INI file: %w", err)
-	}
-	
-	return result, nil
-}
-
-// Marshal is not implemented for INI parser (read-only).
-func (p *INI) Marshal(o map[string]interface{}) ([]byte, error) {
-	return nil, errors.New("not implemented")
-}
-
 // Merge returns a merge function that constructs the URL from host/port fields
 // and extracts the API key from the INI configuration.
 // The INI parser will create nested keys like "misc.api_key", "misc.host", "misc.port".
@@ -113,7 +62,14 @@ func (p *INI) Merge(baseURL string) func(src, dest map[string]interface{}) error
 			var u *url.URL
 			var err error
 			if baseURL != "" {
-				u, err = url.Parse(baseURL)
+				expandedBase, insecure, expandErr := base_config.ExpandURL(baseURL)
+				if expandErr != nil {
+					return fmt.Errorf("failed to expand base URL: %w", expandErr)
+				}
+				if insecure {
+					dest["disable-ssl-verify"] = true
+				}
+				u, err = url.Parse(expandedBase)
 				if err != nil {
 					return fmt.Errorf("failed to parse base URL: %w", err)
 				}
@@ -186,4 +142,3 @@ func convertToString(val interface{}) string {
 		return fmt.Sprintf("%v", v)
 	}
 }
-
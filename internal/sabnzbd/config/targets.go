@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/v2"
+
+	base_config "github.com/onedr0p/exportarr/internal/config"
+	"github.com/onedr0p/exportarr/internal/config/configadapter"
+)
+
+// TargetConfig describes a single named Sabnzbd instance that can be
+// scraped via the exporter's /probe endpoint, blackbox_exporter-style.
+type TargetConfig struct {
+	Name             string `koanf:"name"`
+	URL              string `koanf:"url"`
+	ApiKey           string `koanf:"api-key"`
+	INIConfig        string `koanf:"config"`
+	DisableSSLVerify bool   `koanf:"disable-ssl-verify"`
+}
+
+// TargetsConfig is the top-level shape of the optional targets file used to
+// scrape multiple Sabnzbd instances from a single exporter process.
+type TargetsConfig struct {
+	Targets []TargetConfig `koanf:"targets"`
+}
+
+// LoadTargetsConfig loads a YAML, TOML, or JSON file listing the Sabnzbd
+// instances this exporter should be able to probe. The format is selected
+// via the configadapter registry from the file extension, defaulting to
+// YAML.
+func LoadTargetsConfig(path string) (*TargetsConfig, error) {
+	adapter := configadapter.ForExt(filepath.Ext(path))
+	if adapter == nil {
+		adapter = configadapter.Get("yaml")
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading targets config: %w", err)
+	}
+
+	adapted, warnings, err := adapter.Adapt(body)
+	if err != nil {
+		return nil, fmt.Errorf("loading targets config: %w", err)
+	}
+	for _, w := range warnings {
+		log.Printf("config: %s: %s", path, w.Message)
+	}
+
+	k := koanf.New(".")
+	if err := k.Load(confmap.Provider(adapted, "."), nil); err != nil {
+		return nil, fmt.Errorf("loading targets config: %w", err)
+	}
+
+	out := &TargetsConfig{}
+	if err := k.Unmarshal("", out); err != nil {
+		return nil, err
+	}
+
+	for i, t := range out.Targets {
+		target, insecure, err := base_config.ExpandURL(t.URL)
+		if err != nil {
+			return nil, fmt.Errorf("target %q: %w", t.Name, err)
+		}
+		out.Targets[i].URL = target
+		if insecure {
+			out.Targets[i].DisableSSLVerify = true
+		}
+	}
+
+	return out, nil
+}
+
+// Find returns the named target, or nil if no target by that name is
+// configured.
+func (t *TargetsConfig) Find(name string) *TargetConfig {
+	for i := range t.Targets {
+		if t.Targets[i].Name == name {
+			return &t.Targets[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTargetsConfig_YAML(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "targets.yaml")
+	content := `
+targets:
+  - name: one
+    url: "8080"
+    api-key: abcdef0123456789abcdef0123456789
+  - name: two
+    url: "https+insecure://sab2.example.com"
+    api-key: abcdef0123456789abcdef0123456789abcdef
+`
+	require.NoError(os.WriteFile(path, []byte(content), 0o600))
+
+	targets, err := LoadTargetsConfig(path)
+	require.NoError(err)
+	require.Len(targets.Targets, 2)
+
+	one := targets.Find("one")
+	require.NotNil(one)
+	require.Equal("http://127.0.0.1:8080", one.URL)
+	require.False(one.DisableSSLVerify)
+
+	two := targets.Find("two")
+	require.NotNil(two)
+	require.Equal("https://sab2.example.com", two.URL)
+	require.True(two.DisableSSLVerify)
+
+	require.Nil(targets.Find("missing"))
+}
+
+func TestLoadTargetsConfig_TOML(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "targets.toml")
+	content := `
+[[targets]]
+name = "one"
+url = "sab.example.com:8080"
+api-key = "abcdef0123456789abcdef0123456789"
+config = "/etc/sabnzbd/sabnzbd.ini"
+`
+	require.NoError(os.WriteFile(path, []byte(content), 0o600))
+
+	targets, err := LoadTargetsConfig(path)
+	require.NoError(err)
+	require.Len(targets.Targets, 1)
+	require.Equal("http://sab.example.com:8080", targets.Targets[0].URL)
+	require.Equal("/etc/sabnzbd/sabnzbd.ini", targets.Targets[0].INIConfig)
+}
+
+func TestLoadTargetsConfig_JSON(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "targets.json")
+	content := `{
+		"targets": [
+			{"name": "one", "url": "sab.example.com:8080", "api-key": "abcdef0123456789abcdef0123456789"}
+		]
+	}`
+	require.NoError(os.WriteFile(path, []byte(content), 0o600))
+
+	targets, err := LoadTargetsConfig(path)
+	require.NoError(err)
+	require.Len(targets.Targets, 1)
+	require.Equal("http://sab.example.com:8080", targets.Targets[0].URL)
+}
+
+func TestLoadTargetsConfig_MissingFile(t *testing.T) {
+	require := require.New(t)
+
+	_, err := LoadTargetsConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(err)
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long Watch waits after the last filesystem event
+// before reloading, so a burst of writes from an atomic-rename editor
+// produces a single reload instead of several.
+const watchDebounce = 500 * time.Millisecond
+
+// Watch watches the sabnzbd.ini file this config was loaded from (--config
+// / SAB_CONFIG) for changes and emits a freshly loaded, validated
+// SabnzbdConfig on the returned channel each time it changes. Sabnzbd
+// itself rewrites its ini on port or API key changes, so this lets callers
+// pick up new connection details without restarting.
+//
+// The channel is closed, and the watch stopped, when ctx is done. Invalid
+// configs encountered while watching (e.g. a half-written ini) are skipped
+// rather than sent.
+func (c *SabnzbdConfig) Watch(ctx context.Context) (<-chan *SabnzbdConfig, error) {
+	if c.INIConfig == "" {
+		return nil, fmt.Errorf("sabnzbd: Watch requires a sabnzbd.ini path (--config)")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("sabnzbd: creating fsnotify watcher: %w", err)
+	}
+
+	// Watch the parent directory, not the file itself, so the watch
+	// survives editors that replace the file via an atomic rename.
+	dir := filepath.Dir(c.INIConfig)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("sabnzbd: watching %s: %w", dir, err)
+	}
+
+	out := make(chan *SabnzbdConfig)
+	go c.watchLoop(ctx, watcher, out)
+	return out, nil
+}
+
+func (c *SabnzbdConfig) watchLoop(ctx context.Context, watcher *fsnotify.Watcher, out chan<- *SabnzbdConfig) {
+	defer watcher.Close()
+	defer close(out)
+
+	name := filepath.Base(c.INIConfig)
+	var debounce *time.Timer
+	defer func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+	}()
+
+	for {
+		var debounceC <-chan time.Time
+		if debounce != nil {
+			debounceC = debounce.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Base(event.Name) != name {
+				continue
+			}
+			if debounce == nil {
+				debounce = time.NewTimer(watchDebounce)
+			} else {
+				debounce.Reset(watchDebounce)
+			}
+
+		case <-debounceC:
+			debounce = nil
+			reloaded, err := LoadSabnzbdConfig(c.conf, c.flags)
+			if err != nil {
+				continue
+			}
+			if err := reloaded.Validate(); err != nil {
+				continue
+			}
+			select {
+			case out <- reloaded:
+			case <-ctx.Done():
+				return
+			}
+
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
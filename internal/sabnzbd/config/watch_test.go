@@ -0,0 +1,159 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	base_config "github.com/onedr0p/exportarr/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestIni(t *testing.T, path, port string) {
+	t.Helper()
+	content := "[misc]\nhost = ::\nport = " + port + "\napi_key = abcdef0123456789abcdef0123456789\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestWatch_RequiresConfigPath(t *testing.T) {
+	require := require.New(t)
+
+	c := &SabnzbdConfig{}
+	_, err := c.Watch(context.Background())
+	require.Error(err)
+}
+
+func TestWatch_ReloadsOnChange(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sabnzbd.ini")
+	writeTestIni(t, path, "8080")
+
+	flags := testFlagSet()
+	require.NoError(flags.Set("config", path))
+
+	c, err := LoadSabnzbdConfig(base_config.Config{URL: "http://localhost"}, flags)
+	require.NoError(err)
+	require.Equal("http://localhost:8080", c.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := c.Watch(ctx)
+	require.NoError(err)
+
+	writeTestIni(t, path, "9090")
+
+	select {
+	case updated := <-updates:
+		require.NotNil(updated)
+		require.Equal("http://localhost:9090", updated.URL)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+}
+
+// TestWatch_DebouncesBurstOfWrites asserts that a burst of writes within
+// the debounce window (e.g. an atomic-rename editor touching the file
+// several times) coalesces into a single reload.
+func TestWatch_DebouncesBurstOfWrites(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sabnzbd.ini")
+	writeTestIni(t, path, "8080")
+
+	flags := testFlagSet()
+	require.NoError(flags.Set("config", path))
+
+	c, err := LoadSabnzbdConfig(base_config.Config{URL: "http://localhost"}, flags)
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := c.Watch(ctx)
+	require.NoError(err)
+
+	for i := 0; i < 5; i++ {
+		writeTestIni(t, path, "9090")
+		time.Sleep(watchDebounce / 10)
+	}
+
+	select {
+	case updated := <-updates:
+		require.Equal("http://localhost:9090", updated.URL)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+
+	select {
+	case extra := <-updates:
+		t.Fatalf("expected debounced writes to coalesce into a single reload, got an extra update: %+v", extra)
+	case <-time.After(watchDebounce + 250*time.Millisecond):
+	}
+}
+
+// TestWatch_SurvivesAtomicRename asserts the watch keeps working after the
+// ini is replaced via an atomic rename (as editors and Sabnzbd itself do),
+// since Watch adds the parent directory rather than the file itself.
+func TestWatch_SurvivesAtomicRename(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sabnzbd.ini")
+	writeTestIni(t, path, "8080")
+
+	flags := testFlagSet()
+	require.NoError(flags.Set("config", path))
+
+	c, err := LoadSabnzbdConfig(base_config.Config{URL: "http://localhost"}, flags)
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := c.Watch(ctx)
+	require.NoError(err)
+
+	tmp := filepath.Join(dir, "sabnzbd.ini.tmp")
+	writeTestIni(t, tmp, "9090")
+	require.NoError(os.Rename(tmp, path))
+
+	select {
+	case updated := <-updates:
+		require.Equal("http://localhost:9090", updated.URL)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for reload after atomic rename")
+	}
+}
+
+func TestWatch_ClosesChannelWhenContextDone(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sabnzbd.ini")
+	writeTestIni(t, path, "8080")
+
+	flags := testFlagSet()
+	require.NoError(flags.Set("config", path))
+
+	c, err := LoadSabnzbdConfig(base_config.Config{URL: "http://localhost"}, flags)
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := c.Watch(ctx)
+	require.NoError(err)
+
+	cancel()
+
+	select {
+	case _, ok := <-updates:
+		require.False(ok)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+}